@@ -0,0 +1,47 @@
+// Copyright 2022 SundaeSwap Labs, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software
+// is furnished to do so, subject to the following conditions:
+//
+// Licensed under the MIT License;
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://opensource.org/licenses/MIT
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kugo
+
+import "net/http"
+
+// Client queries a Kupo chain-index instance over HTTP and WebSocket.
+// Its underlying http.Client is built once and shared across requests,
+// so connections are pooled rather than re-dialed on every call.
+type Client struct {
+	options    options
+	httpClient *http.Client
+}
+
+// New creates a Client configured by the given Options, defaulting to
+// http://localhost:1442 when no endpoint is provided.
+func New(opts ...Option) *Client {
+	o := newOptions(opts...)
+	return &Client{
+		options: o,
+		httpClient: &http.Client{
+			Timeout:   o.timeout,
+			Transport: o.transport,
+		},
+	}
+}
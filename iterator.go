@@ -0,0 +1,237 @@
+// Copyright 2022 SundaeSwap Labs, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software
+// is furnished to do so, subject to the following conditions:
+//
+// Licensed under the MIT License;
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://opensource.org/licenses/MIT
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kugo
+
+import (
+	"context"
+	"fmt"
+)
+
+// defaultWindowSlots is the width, in slots, of each page MatchesIter
+// requests from Kupo. Narrower windows bound memory at the cost of more
+// round trips.
+const defaultWindowSlots = 10_000
+
+// Cursor identifies a precise position within MatchesIter's traversal.
+// A bare slot is not enough to resume safely: many matches commonly
+// share a slot, so resuming from Checkpoint().Slot alone could skip
+// sibling matches in that slot sorted after the one last consumed.
+type Cursor struct {
+	Slot             uint64
+	TransactionIndex int
+	OutputIndex      int
+}
+
+// MatchIterator walks the matches for a pattern in bounded memory,
+// paging through Kupo's created_after/created_before slot filters.
+// Callers call Next until it returns false, then check Err.
+type MatchIterator interface {
+	// Next advances to the next match, fetching additional pages as
+	// needed. It returns false once the pattern is exhausted, ctx is
+	// cancelled, or an error occurs.
+	Next(ctx context.Context) bool
+	// Match returns the match most recently yielded by Next.
+	Match() Match
+	// Checkpoint returns the cursor of the last-consumed match, so a
+	// caller can resume iteration after a crash via WithResumeFrom.
+	Checkpoint() Cursor
+	// Err returns the first error encountered, if any.
+	Err() error
+}
+
+type matchIteratorOptions struct {
+	windowSlots uint64
+	resumeFrom  *Cursor
+}
+
+// MatchesIterOption configures MatchesIter.
+type MatchesIterOption func(*matchIteratorOptions)
+
+// WithWindowSlots overrides the slot width of each page fetched from
+// Kupo. Defaults to 10,000 slots.
+func WithWindowSlots(slots uint64) MatchesIterOption {
+	return func(o *matchIteratorOptions) {
+		o.windowSlots = slots
+	}
+}
+
+// WithResumeFrom resumes iteration from a cursor previously returned by
+// Iterator.Checkpoint(), re-fetching cursor.Slot's window and skipping
+// through to the exact match the cursor points at so sibling matches in
+// the same slot are neither skipped nor repeated.
+func WithResumeFrom(cursor Cursor) MatchesIterOption {
+	return func(o *matchIteratorOptions) {
+		o.resumeFrom = &cursor
+	}
+}
+
+type matchIterator struct {
+	client  *Client
+	pattern string
+	cfg     matchIteratorOptions
+
+	tip       uint64
+	windowEnd uint64
+
+	// skipThrough, while non-nil, filters out matches at or before the
+	// resumed cursor's position within its slot. It applies only to the
+	// first window fetched after a resume, then is cleared.
+	skipThrough *Cursor
+
+	buf []Match
+	idx int
+
+	cur Match
+
+	done bool
+	err  error
+}
+
+// MatchesIter returns a MatchIterator that walks all matches for
+// pattern in bounded memory by paging Kupo's created_after/created_before
+// slot filters, using Checkpoints to discover the current tip.
+func (c *Client) MatchesIter(
+	ctx context.Context,
+	pattern string,
+	opts ...MatchesIterOption,
+) (MatchIterator, error) {
+	cfg := matchIteratorOptions{windowSlots: defaultWindowSlots}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.windowSlots == 0 {
+		return nil, fmt.Errorf("window slots must be greater than zero")
+	}
+
+	checkpoints, err := c.Checkpoints(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine tip for MatchesIter: %w", err)
+	}
+	if len(checkpoints) == 0 {
+		return &matchIterator{done: true}, nil
+	}
+
+	var windowEnd uint64
+	var skipThrough *Cursor
+	if cfg.resumeFrom != nil {
+		resume := *cfg.resumeFrom
+		skipThrough = &resume
+		if resume.Slot > 0 {
+			windowEnd = resume.Slot - 1
+		}
+	}
+
+	return &matchIterator{
+		client:      c,
+		pattern:     pattern,
+		cfg:         cfg,
+		tip:         checkpoints[0].Slot,
+		windowEnd:   windowEnd,
+		skipThrough: skipThrough,
+	}, nil
+}
+
+func (it *matchIterator) Next(ctx context.Context) bool {
+	if it.done {
+		return false
+	}
+	if err := ctx.Err(); err != nil {
+		it.err = err
+		it.done = true
+		return false
+	}
+	for it.idx >= len(it.buf) {
+		if it.windowEnd >= it.tip {
+			it.done = true
+			return false
+		}
+
+		start := it.windowEnd
+		end := start + it.cfg.windowSlots
+		if end > it.tip {
+			end = it.tip
+		}
+
+		matches, err := it.client.Matches(
+			ctx,
+			it.pattern,
+			WithCreatedAfter(start),
+			WithCreatedBefore(end),
+		)
+		if err != nil {
+			it.err = fmt.Errorf("unable to fetch matches window [%d,%d]: %w", start, end, err)
+			it.done = true
+			return false
+		}
+
+		if it.skipThrough != nil {
+			matches = skipToCursor(matches, *it.skipThrough)
+			it.skipThrough = nil
+		}
+
+		it.buf = matches
+		it.idx = 0
+		it.windowEnd = end
+	}
+
+	it.cur = it.buf[it.idx]
+	it.idx++
+	return true
+}
+
+// skipToCursor drops every match at or before cursor within cursor's
+// own slot, leaving matches in earlier slots (already consumed) out and
+// matches in later slots untouched.
+func skipToCursor(matches []Match, cursor Cursor) []Match {
+	filtered := matches[:0]
+	for _, m := range matches {
+		if m.CreatedAt.Slot < cursor.Slot {
+			continue
+		}
+		if m.CreatedAt.Slot == cursor.Slot && !matchAfterCursor(m, cursor) {
+			continue
+		}
+		filtered = append(filtered, m)
+	}
+	return filtered
+}
+
+func matchAfterCursor(m Match, cursor Cursor) bool {
+	if m.TransactionIndex != cursor.TransactionIndex {
+		return m.TransactionIndex > cursor.TransactionIndex
+	}
+	return m.OutputIndex > cursor.OutputIndex
+}
+
+func (it *matchIterator) Match() Match { return it.cur }
+
+func (it *matchIterator) Checkpoint() Cursor {
+	return Cursor{
+		Slot:             it.cur.CreatedAt.Slot,
+		TransactionIndex: it.cur.TransactionIndex,
+		OutputIndex:      it.cur.OutputIndex,
+	}
+}
+
+func (it *matchIterator) Err() error { return it.err }
@@ -0,0 +1,59 @@
+// Copyright 2022 SundaeSwap Labs, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software
+// is furnished to do so, subject to the following conditions:
+//
+// Licensed under the MIT License;
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://opensource.org/licenses/MIT
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kugo
+
+// Point identifies a point on the chain by slot number and block header
+// hash, as returned by Kupo's created_at/spent_at/checkpoints fields.
+type Point struct {
+	Slot   uint64 `json:"slot_no"`
+	Header string `json:"header_hash"`
+}
+
+// SpentPoint extends Point with the identity of the transaction that
+// spent a matched UTxO.
+type SpentPoint struct {
+	Point
+	TransactionID string `json:"transaction_id"`
+	InputIndex    int    `json:"input_index"`
+}
+
+// Value is the ada and native asset quantities locked at a UTxO.
+type Value struct {
+	Coins  uint64            `json:"coins"`
+	Assets map[string]uint64 `json:"assets,omitempty"`
+}
+
+// Match is a single UTxO entry returned by Kupo's /v1/matches endpoint.
+type Match struct {
+	TransactionIndex int         `json:"transaction_index"`
+	TransactionID    string      `json:"transaction_id"`
+	OutputIndex      int         `json:"output_index"`
+	Address          string      `json:"address"`
+	Value            Value       `json:"value"`
+	DatumHash        string      `json:"datum_hash,omitempty"`
+	DatumType        string      `json:"datum_type,omitempty"`
+	ScriptHash       string      `json:"script_hash,omitempty"`
+	CreatedAt        Point       `json:"created_at"`
+	SpentAt          *SpentPoint `json:"spent_at,omitempty"`
+}
@@ -0,0 +1,130 @@
+// Copyright 2022 SundaeSwap Labs, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software
+// is furnished to do so, subject to the following conditions:
+//
+// Licensed under the MIT License;
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://opensource.org/licenses/MIT
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kugo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/SundaeSwap-finance/ogmigo/v6"
+)
+
+type matchesOptions struct {
+	createdAfter  *uint64
+	createdBefore *uint64
+}
+
+// MatchesOption configures a single Matches call.
+type MatchesOption func(*matchesOptions)
+
+// WithCreatedAfter restricts results to matches created after the given
+// slot, exclusive.
+func WithCreatedAfter(slot uint64) MatchesOption {
+	return func(o *matchesOptions) {
+		o.createdAfter = &slot
+	}
+}
+
+// WithCreatedBefore restricts results to matches created at or before
+// the given slot.
+func WithCreatedBefore(slot uint64) MatchesOption {
+	return func(o *matchesOptions) {
+		o.createdBefore = &slot
+	}
+}
+
+// Matches fetches every match for pattern as a single response. For
+// patterns with very large result sets, prefer MatchesIter, which pages
+// the result by slot window instead of buffering it all at once.
+func (c *Client) Matches(
+	ctx context.Context,
+	pattern string,
+	opts ...MatchesOption,
+) (matches []Match, err error) {
+	start := time.Now()
+	defer func() {
+		errStr := ""
+		if err != nil {
+			errStr = err.Error()
+		}
+		c.options.logger.Info(
+			"Matches() finished",
+			ogmigo.KV(
+				"duration",
+				time.Since(start).Round(time.Millisecond).String(),
+			),
+			ogmigo.KV("err", errStr),
+		)
+	}()
+
+	var cfg matchesOptions
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	u, err := url.Parse(c.options.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"unable to parse endpoint %v: %w",
+			c.options.endpoint,
+			err,
+		)
+	}
+	u.Path = "/v1/matches/" + pattern
+
+	q := u.Query()
+	if cfg.createdAfter != nil {
+		q.Set("created_after", strconv.FormatUint(*cfg.createdAfter, 10))
+	}
+	if cfg.createdBefore != nil {
+		q.Set("created_before", strconv.FormatUint(*cfg.createdBefore, 10))
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build request: %w", err)
+	}
+
+	resp, err := c.doRequest(ctx, req, requestAttrs{endpoint: "Matches", pattern: pattern})
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch matches: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	if err := json.Unmarshal(body, &matches); err != nil {
+		return nil, fmt.Errorf("unable to parse body %s: %w", body, err)
+	}
+	return matches, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2022 SundaeSwap Labs, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software
+// is furnished to do so, subject to the following conditions:
+//
+// Licensed under the MIT License;
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://opensource.org/licenses/MIT
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kugo
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// clientMetrics holds the Prometheus collectors registered for a
+// Client via WithMetrics, labeled by endpoint (e.g. "Script", "Matches").
+type clientMetrics struct {
+	requestDuration *prometheus.HistogramVec
+	requestErrors   *prometheus.CounterVec
+	inFlight        *prometheus.GaugeVec
+}
+
+func newClientMetrics(reg prometheus.Registerer) *clientMetrics {
+	m := &clientMetrics{
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "kugo",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of Kupo HTTP requests, by endpoint.",
+		}, []string{"endpoint"}),
+		requestErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "kugo",
+			Name:      "request_errors_total",
+			Help:      "Count of failed Kupo HTTP requests, by endpoint and status code.",
+		}, []string{"endpoint", "status_code"}),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "kugo",
+			Name:      "requests_in_flight",
+			Help:      "Number of in-flight Kupo HTTP requests, by endpoint.",
+		}, []string{"endpoint"}),
+	}
+	reg.MustRegister(m.requestDuration, m.requestErrors, m.inFlight)
+	return m
+}
@@ -0,0 +1,219 @@
+// Copyright 2022 SundaeSwap Labs, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software
+// is furnished to do so, subject to the following conditions:
+//
+// Licensed under the MIT License;
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://opensource.org/licenses/MIT
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kugo
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// NativeScriptType identifies the kind of a NativeScript node, per the
+// Shelley/Allegra native_script CDDL.
+type NativeScriptType int
+
+const (
+	NativeScriptSig NativeScriptType = iota
+	NativeScriptAll
+	NativeScriptAny
+	NativeScriptAtLeast
+	NativeScriptAfter
+	NativeScriptBefore
+)
+
+// NativeScript is a parsed Shelley/Allegra native script, as used for
+// multi-signature wallets and time-locked outputs.
+type NativeScript struct {
+	Type NativeScriptType
+
+	// KeyHash is set when Type is NativeScriptSig.
+	KeyHash []byte
+
+	// Scripts holds the child scripts for All, Any, and AtLeast.
+	Scripts []*NativeScript
+
+	// Required is the minimum number of Scripts that must evaluate true,
+	// set when Type is NativeScriptAtLeast.
+	Required int
+
+	// Slot is the boundary slot, set when Type is NativeScriptAfter or
+	// NativeScriptBefore.
+	Slot uint64
+}
+
+// EvalContext supplies the chain state a NativeScript is evaluated
+// against.
+type EvalContext struct {
+	// Slot is the current slot, compared against After/Before nodes.
+	Slot uint64
+	// SigningKeyHashes is the set of hex-encoded key hashes considered
+	// to have signed, checked against Sig nodes.
+	SigningKeyHashes map[string]bool
+}
+
+// DecodeNative CBOR-decodes s.Script as a native script. The underlying
+// hex is left untouched, so Hash continues to operate on the same
+// bytes. It returns an error if s.Language is not ScriptLanguageNative.
+func (s *Script) DecodeNative() (*NativeScript, error) {
+	if s.Language != ScriptLanguageNative {
+		return nil, fmt.Errorf("script language %v is not native", s.Language)
+	}
+	raw, err := hex.DecodeString(s.Script)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode script hex: %w", err)
+	}
+	return decodeNativeScript(raw)
+}
+
+func decodeNativeScript(raw []byte) (*NativeScript, error) {
+	var fields []cbor.RawMessage
+	if err := cbor.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("unable to decode native script: %w", err)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty native script")
+	}
+
+	var tag int
+	if err := cbor.Unmarshal(fields[0], &tag); err != nil {
+		return nil, fmt.Errorf("unable to decode native script tag: %w", err)
+	}
+
+	// Every tag below reads at least fields[1] (tag 3 also reads
+	// fields[2]); reject truncated arrays up front rather than let a
+	// malformed or adversarial CBOR blob panic on an out-of-range index.
+	minFields := 2
+	if tag == 3 {
+		minFields = 3
+	}
+	if len(fields) < minFields {
+		return nil, fmt.Errorf(
+			"native script tag %v expects at least %d fields, got %d",
+			tag,
+			minFields,
+			len(fields),
+		)
+	}
+
+	switch tag {
+	case 0:
+		var keyHash []byte
+		if err := cbor.Unmarshal(fields[1], &keyHash); err != nil {
+			return nil, fmt.Errorf("unable to decode sig key hash: %w", err)
+		}
+		return &NativeScript{Type: NativeScriptSig, KeyHash: keyHash}, nil
+	case 1, 2:
+		children, err := decodeNativeScriptList(fields[1])
+		if err != nil {
+			return nil, err
+		}
+		t := NativeScriptAll
+		if tag == 2 {
+			t = NativeScriptAny
+		}
+		return &NativeScript{Type: t, Scripts: children}, nil
+	case 3:
+		var required int
+		if err := cbor.Unmarshal(fields[1], &required); err != nil {
+			return nil, fmt.Errorf("unable to decode at-least threshold: %w", err)
+		}
+		children, err := decodeNativeScriptList(fields[2])
+		if err != nil {
+			return nil, err
+		}
+		return &NativeScript{
+			Type:     NativeScriptAtLeast,
+			Required: required,
+			Scripts:  children,
+		}, nil
+	case 4:
+		var slot uint64
+		if err := cbor.Unmarshal(fields[1], &slot); err != nil {
+			return nil, fmt.Errorf("unable to decode after-slot bound: %w", err)
+		}
+		return &NativeScript{Type: NativeScriptAfter, Slot: slot}, nil
+	case 5:
+		var slot uint64
+		if err := cbor.Unmarshal(fields[1], &slot); err != nil {
+			return nil, fmt.Errorf("unable to decode before-slot bound: %w", err)
+		}
+		return &NativeScript{Type: NativeScriptBefore, Slot: slot}, nil
+	default:
+		return nil, fmt.Errorf("unknown native script tag: %v", tag)
+	}
+}
+
+func decodeNativeScriptList(raw cbor.RawMessage) ([]*NativeScript, error) {
+	var rawChildren []cbor.RawMessage
+	if err := cbor.Unmarshal(raw, &rawChildren); err != nil {
+		return nil, fmt.Errorf("unable to decode native script list: %w", err)
+	}
+	children := make([]*NativeScript, 0, len(rawChildren))
+	for _, rawChild := range rawChildren {
+		child, err := decodeNativeScript(rawChild)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+	return children, nil
+}
+
+// Evaluate reports whether ns is satisfied under ctx, recursively
+// evaluating any child scripts.
+func (ns *NativeScript) Evaluate(ctx EvalContext) bool {
+	switch ns.Type {
+	case NativeScriptSig:
+		return ctx.SigningKeyHashes[hex.EncodeToString(ns.KeyHash)]
+	case NativeScriptAll:
+		for _, child := range ns.Scripts {
+			if !child.Evaluate(ctx) {
+				return false
+			}
+		}
+		return true
+	case NativeScriptAny:
+		for _, child := range ns.Scripts {
+			if child.Evaluate(ctx) {
+				return true
+			}
+		}
+		return false
+	case NativeScriptAtLeast:
+		satisfied := 0
+		for _, child := range ns.Scripts {
+			if child.Evaluate(ctx) {
+				satisfied++
+			}
+		}
+		return satisfied >= ns.Required
+	case NativeScriptAfter:
+		return ctx.Slot >= ns.Slot
+	case NativeScriptBefore:
+		return ctx.Slot < ns.Slot
+	default:
+		return false
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2022 SundaeSwap Labs, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software
+// is furnished to do so, subject to the following conditions:
+//
+// Licensed under the MIT License;
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://opensource.org/licenses/MIT
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kugo
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+func TestDecodeNativeRejectsTruncatedArrays(t *testing.T) {
+	cases := []struct {
+		name string
+		tag  []interface{}
+	}{
+		{"sig missing key hash", []interface{}{0}},
+		{"all missing script list", []interface{}{1}},
+		{"any missing script list", []interface{}{2}},
+		{"at-least missing scripts", []interface{}{3, 2}},
+		{"after missing slot", []interface{}{4}},
+		{"before missing slot", []interface{}{5}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw, err := cbor.Marshal(tc.tag)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			s := &Script{Language: ScriptLanguageNative, Script: hex.EncodeToString(raw)}
+			if _, err := s.DecodeNative(); err == nil {
+				t.Fatal("expected an error for a truncated native script, got nil")
+			}
+		})
+	}
+}
+
+func TestDecodeNativeSigEvaluate(t *testing.T) {
+	keyHash := []byte{0x01, 0x02, 0x03}
+	raw, err := cbor.Marshal([]interface{}{0, keyHash})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	s := &Script{Language: ScriptLanguageNative, Script: hex.EncodeToString(raw)}
+
+	ns, err := s.DecodeNative()
+	if err != nil {
+		t.Fatalf("DecodeNative: %v", err)
+	}
+
+	signed := hex.EncodeToString(keyHash)
+	if !ns.Evaluate(EvalContext{SigningKeyHashes: map[string]bool{signed: true}}) {
+		t.Fatal("expected sig script to evaluate true when the key hash signed")
+	}
+	if ns.Evaluate(EvalContext{SigningKeyHashes: map[string]bool{}}) {
+		t.Fatal("expected sig script to evaluate false when the key hash did not sign")
+	}
+}
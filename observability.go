@@ -0,0 +1,138 @@
+// Copyright 2022 SundaeSwap Labs, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software
+// is furnished to do so, subject to the following conditions:
+//
+// Licensed under the MIT License;
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://opensource.org/licenses/MIT
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kugo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/SundaeSwap-finance/kugo")
+
+// requestAttrs carries endpoint-specific attributes applied to both the
+// trace span and the Prometheus labels for a single HTTP call.
+type requestAttrs struct {
+	endpoint   string
+	pattern    string
+	scriptHash string
+}
+
+// doRequest executes req wrapped in a trace span and, if WithMetrics was
+// configured, latency/error/in-flight instrumentation. It is the single
+// choke point every endpoint method calls through, so callers only need
+// to build the request and describe it via requestAttrs.
+func (c *Client) doRequest(
+	ctx context.Context,
+	req *http.Request,
+	attrs requestAttrs,
+) (*http.Response, error) {
+	spanAttrs := []attribute.KeyValue{
+		attribute.String("kupo.endpoint", attrs.endpoint),
+	}
+	if attrs.pattern != "" {
+		spanAttrs = append(spanAttrs, attribute.String("kupo.pattern", attrs.pattern))
+	}
+	if attrs.scriptHash != "" {
+		spanAttrs = append(spanAttrs, attribute.String("kupo.script_hash", attrs.scriptHash))
+	}
+
+	ctx, span := tracer.Start(ctx, "kugo."+attrs.endpoint, trace.WithAttributes(spanAttrs...))
+	defer span.End()
+	req = req.WithContext(ctx)
+
+	if c.options.metrics != nil {
+		c.options.metrics.inFlight.WithLabelValues(attrs.endpoint).Inc()
+		defer c.options.metrics.inFlight.WithLabelValues(attrs.endpoint).Dec()
+	}
+
+	policy := c.options.retry
+	backoff := policy.MinBackoff
+
+	var resp *http.Response
+	var err error
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		resp, err = c.httpClient.Do(req)
+		duration := time.Since(start).Seconds()
+
+		if c.options.metrics != nil {
+			c.options.metrics.requestDuration.WithLabelValues(attrs.endpoint).Observe(duration)
+			if err != nil {
+				c.options.metrics.requestErrors.WithLabelValues(attrs.endpoint, "error").Inc()
+			} else if resp.StatusCode >= 400 {
+				c.options.metrics.requestErrors.WithLabelValues(
+					attrs.endpoint,
+					strconv.Itoa(resp.StatusCode),
+				).Inc()
+			}
+		}
+
+		if err == nil && !shouldRetry(resp, nil) {
+			break
+		}
+
+		if attempt >= policy.MaxAttempts {
+			break
+		}
+
+		wait := jitter(backoff)
+		if err == nil {
+			if ra := retryAfter(resp); ra > 0 {
+				wait = ra
+			}
+			resp.Body.Close()
+		}
+		if waitErr := sleepOrDone(ctx, wait); waitErr != nil {
+			return nil, waitErr
+		}
+
+		backoff *= 2
+		if backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if resp == nil {
+		err := errors.New("failed with a nil response")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+	return resp, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2022 SundaeSwap Labs, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software
+// is furnished to do so, subject to the following conditions:
+//
+// Licensed under the MIT License;
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://opensource.org/licenses/MIT
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kugo
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/SundaeSwap-finance/ogmigo/v6"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// options holds the configurable behavior of a Client, built up by the
+// With* functions below.
+type options struct {
+	endpoint    string
+	timeout     time.Duration
+	logger      ogmigo.Logger
+	scriptCache ScriptCache
+	metrics     *clientMetrics
+	transport   *http.Transport
+	retry       RetryPolicy
+}
+
+// Option configures a Client.
+type Option func(*options)
+
+// WithEndpoint sets the base URL of the Kupo instance to query.
+func WithEndpoint(endpoint string) Option {
+	return func(o *options) {
+		o.endpoint = endpoint
+	}
+}
+
+// WithTimeout sets the per-request timeout applied to HTTP calls.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithLogger sets the logger used for request tracing.
+func WithLogger(logger ogmigo.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithScriptCache configures a ScriptCache that Script consults before
+// hitting Kupo, and populates after a successful lookup. Since scripts
+// are immutable and content-addressed, entries never need eviction on
+// correctness grounds.
+func WithScriptCache(cache ScriptCache) Option {
+	return func(o *options) {
+		o.scriptCache = cache
+	}
+}
+
+// WithMetrics registers Prometheus collectors on reg and instruments
+// every Client request with request latency, in-flight gauges, and
+// error counts by status code, all labeled by endpoint.
+func WithMetrics(reg prometheus.Registerer) Option {
+	return func(o *options) {
+		o.metrics = newClientMetrics(reg)
+	}
+}
+
+// WithTransport overrides the http.Transport the Client's shared
+// http.Client is built from, letting callers tune things like
+// MaxIdleConnsPerHost or TLSClientConfig.
+func WithTransport(transport *http.Transport) Option {
+	return func(o *options) {
+		o.transport = transport
+	}
+}
+
+// WithRetry configures exponential backoff retries on 5xx responses,
+// network errors, and 429s (honoring a Retry-After header when
+// present). By default a Client makes a single attempt.
+func WithRetry(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = policy
+	}
+}
+
+func newOptions(opts ...Option) options {
+	o := options{
+		endpoint: "http://localhost:1442",
+		timeout:  30 * time.Second,
+		logger:   ogmigo.NopLogger,
+		transport: &http.Transport{
+			MaxIdleConnsPerHost: 10,
+		},
+		retry: defaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
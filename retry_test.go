@@ -0,0 +1,118 @@
+// Copyright 2022 SundaeSwap Labs, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software
+// is furnished to do so, subject to the following conditions:
+//
+// Licensed under the MIT License;
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://opensource.org/licenses/MIT
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kugo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestShouldRetry(t *testing.T) {
+	cases := []struct {
+		name   string
+		status int
+		err    error
+		want   bool
+	}{
+		{name: "network error", err: errors.New("boom"), want: true},
+		{name: "500", status: http.StatusInternalServerError, want: true},
+		{name: "429", status: http.StatusTooManyRequests, want: true},
+		{name: "200", status: http.StatusOK, want: false},
+		{name: "404", status: http.StatusNotFound, want: false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var resp *http.Response
+			if tc.err == nil {
+				resp = &http.Response{StatusCode: tc.status}
+			}
+			if got := shouldRetry(resp, tc.err); got != tc.want {
+				t.Fatalf("shouldRetry(%v, %v) = %v, want %v", tc.status, tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRetryAfterSeconds(t *testing.T) {
+	resp := httptest.NewRecorder()
+	resp.Header().Set("Retry-After", "2")
+	got := retryAfter(resp.Result())
+	if got != 2*time.Second {
+		t.Fatalf("expected 2s, got %v", got)
+	}
+}
+
+func TestRetryAfterHTTPDate(t *testing.T) {
+	when := time.Now().Add(5 * time.Second)
+	resp := httptest.NewRecorder()
+	resp.Header().Set("Retry-After", when.UTC().Format(http.TimeFormat))
+	got := retryAfter(resp.Result())
+	if got <= 0 || got > 5*time.Second {
+		t.Fatalf("expected a positive duration close to 5s, got %v", got)
+	}
+}
+
+func TestRetryAfterMissingOrInvalid(t *testing.T) {
+	resp := httptest.NewRecorder()
+	if got := retryAfter(resp.Result()); got != 0 {
+		t.Fatalf("expected 0 with no header, got %v", got)
+	}
+
+	resp2 := httptest.NewRecorder()
+	resp2.Header().Set("Retry-After", "not-a-duration")
+	if got := retryAfter(resp2.Result()); got != 0 {
+		t.Fatalf("expected 0 for an unparseable header, got %v", got)
+	}
+}
+
+func TestJitterBounds(t *testing.T) {
+	d := 100 * time.Millisecond
+	for i := 0; i < 50; i++ {
+		got := jitter(d)
+		if got < d/2 || got > d {
+			t.Fatalf("jitter(%v) = %v, want within [%v, %v]", d, got, d/2, d)
+		}
+	}
+	if got := jitter(0); got != 0 {
+		t.Fatalf("jitter(0) = %v, want 0", got)
+	}
+}
+
+func TestSleepOrDoneReturnsEarlyOnCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := sleepOrDone(ctx, time.Minute); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestSleepOrDoneZeroIsNoop(t *testing.T) {
+	if err := sleepOrDone(context.Background(), 0); err != nil {
+		t.Fatalf("expected nil error for a zero duration, got %v", err)
+	}
+}
@@ -27,7 +27,6 @@ import (
 	"context"
 	"encoding/hex"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -142,6 +141,12 @@ func (c *Client) Script(
 		)
 	}()
 
+	if c.options.scriptCache != nil {
+		if cached, ok, cacheErr := c.options.scriptCache.Get(scriptHash); cacheErr == nil && ok {
+			return cached, nil
+		}
+	}
+
 	url, err := url.Parse(c.options.endpoint)
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -157,19 +162,10 @@ func (c *Client) Script(
 		return nil, fmt.Errorf("unable to build request: %w", err)
 	}
 
-	req.Close = true
-	req = req.WithContext(ctx)
-
-	client := &http.Client{
-		Timeout: c.options.timeout,
-	}
-	resp, err := client.Do(req)
+	resp, err := c.doRequest(ctx, req, requestAttrs{endpoint: "Script", scriptHash: scriptHash})
 	if err != nil {
 		return nil, fmt.Errorf("unable to fetch script: %w", err)
 	}
-	if resp == nil {
-		return nil, errors.New("failed with a nil response")
-	}
 	defer resp.Body.Close()
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -180,5 +176,12 @@ func (c *Client) Script(
 	if err := json.Unmarshal(body, &response); err != nil {
 		return response, fmt.Errorf("unable to parse body %s: %w", body, err)
 	}
+
+	if c.options.scriptCache != nil {
+		if err := c.options.scriptCache.Put(scriptHash, response); err != nil {
+			return response, fmt.Errorf("unable to populate script cache: %w", err)
+		}
+	}
+
 	return response, nil
 }
@@ -0,0 +1,57 @@
+// Copyright 2022 SundaeSwap Labs, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software
+// is furnished to do so, subject to the following conditions:
+//
+// Licensed under the MIT License;
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://opensource.org/licenses/MIT
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kugo
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// ScriptCache is a pluggable store for resolved Script lookups, keyed by
+// the script's hex-encoded hash. Since scripts are content-addressed and
+// immutable, a cache hit never needs to be invalidated.
+type ScriptCache interface {
+	// Get returns the cached script for hash, if any.
+	Get(hash string) (*Script, bool, error)
+	// Put stores s under hash. Implementations must verify s actually
+	// hashes to hash and reject the write otherwise, since hash comes
+	// from the cache key a caller chooses and s is untrusted response
+	// data that could disagree with it.
+	Put(hash string, s *Script) error
+}
+
+// verifyScriptHash confirms s hashes to hash, hex-encoded. ScriptCache
+// implementations call this at the top of Put so the hash-verification
+// defense holds regardless of caller, rather than relying on every call
+// site to check it first.
+func verifyScriptHash(hash string, s *Script) error {
+	if got := hex.EncodeToString(s.Hash()); got != hash {
+		return fmt.Errorf(
+			"refusing to cache script %v: hash mismatch, got %v",
+			hash,
+			got,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2022 SundaeSwap Labs, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software
+// is furnished to do so, subject to the following conditions:
+//
+// Licensed under the MIT License;
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://opensource.org/licenses/MIT
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kugo
+
+import (
+	"encoding/json"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var scriptCacheBucket = []byte("scripts")
+
+type boltScriptCache struct {
+	db *bolt.DB
+}
+
+// NewBoltScriptCache opens (creating if necessary) a BoltDB-backed
+// ScriptCache rooted at path, suitable for persisting resolved scripts
+// across process restarts. Callers are responsible for calling Close
+// when done with it.
+func NewBoltScriptCache(path string) (*boltScriptCache, error) {
+	db, err := bolt.Open(path, 0o600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open script cache at %v: %w", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(scriptCacheBucket)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize script cache bucket: %w", err)
+	}
+	return &boltScriptCache{db: db}, nil
+}
+
+func (c *boltScriptCache) Get(hash string) (script *Script, found bool, err error) {
+	err = c.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(scriptCacheBucket).Get([]byte(hash))
+		if data == nil {
+			return nil
+		}
+		found = true
+		script = &Script{}
+		return json.Unmarshal(data, script)
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to read script cache entry %v: %w", hash, err)
+	}
+	return script, found, nil
+}
+
+func (c *boltScriptCache) Put(hash string, s *Script) error {
+	if err := verifyScriptHash(hash, s); err != nil {
+		return err
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("unable to marshal script for cache: %w", err)
+	}
+	return c.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(scriptCacheBucket).Put([]byte(hash), data)
+	})
+}
+
+// Close releases the underlying BoltDB handle.
+func (c *boltScriptCache) Close() error {
+	return c.db.Close()
+}
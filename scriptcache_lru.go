@@ -0,0 +1,57 @@
+// Copyright 2022 SundaeSwap Labs, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software
+// is furnished to do so, subject to the following conditions:
+//
+// Licensed under the MIT License;
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://opensource.org/licenses/MIT
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kugo
+
+import (
+	"fmt"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+type lruScriptCache struct {
+	cache *lru.Cache[string, *Script]
+}
+
+// NewLRUScriptCache returns an in-memory ScriptCache holding up to size
+// most-recently-used scripts.
+func NewLRUScriptCache(size int) (ScriptCache, error) {
+	cache, err := lru.New[string, *Script](size)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create LRU script cache: %w", err)
+	}
+	return &lruScriptCache{cache: cache}, nil
+}
+
+func (c *lruScriptCache) Get(hash string) (*Script, bool, error) {
+	s, ok := c.cache.Get(hash)
+	return s, ok, nil
+}
+
+func (c *lruScriptCache) Put(hash string, s *Script) error {
+	if err := verifyScriptHash(hash, s); err != nil {
+		return err
+	}
+	c.cache.Add(hash, s)
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2022 SundaeSwap Labs, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software
+// is furnished to do so, subject to the following conditions:
+//
+// Licensed under the MIT License;
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://opensource.org/licenses/MIT
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kugo
+
+import (
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+)
+
+// scriptCacheFactories lets the round-trip and hash-verification tests
+// below run identically against every ScriptCache implementation.
+func scriptCacheFactories(t *testing.T) map[string]func() ScriptCache {
+	return map[string]func() ScriptCache{
+		"lru": func() ScriptCache {
+			cache, err := NewLRUScriptCache(8)
+			if err != nil {
+				t.Fatalf("unable to create LRU script cache: %v", err)
+			}
+			return cache
+		},
+		"bolt": func() ScriptCache {
+			cache, err := NewBoltScriptCache(filepath.Join(t.TempDir(), "scripts.db"))
+			if err != nil {
+				t.Fatalf("unable to create bolt script cache: %v", err)
+			}
+			t.Cleanup(func() { cache.Close() })
+			return cache
+		},
+	}
+}
+
+func TestScriptCachePutGetRoundTrip(t *testing.T) {
+	script := &Script{Language: ScriptLanguageNative, Script: "deadbeef"}
+	hash := hex.EncodeToString(script.Hash())
+
+	for name, newCache := range scriptCacheFactories(t) {
+		t.Run(name, func(t *testing.T) {
+			cache := newCache()
+
+			if err := cache.Put(hash, script); err != nil {
+				t.Fatalf("Put: %v", err)
+			}
+
+			got, ok, err := cache.Get(hash)
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if !ok {
+				t.Fatal("expected a cache hit after Put")
+			}
+			if got.Script != script.Script || got.Language != script.Language {
+				t.Fatalf("got %+v, want %+v", got, script)
+			}
+		})
+	}
+}
+
+func TestScriptCacheGetMissReturnsNotFound(t *testing.T) {
+	for name, newCache := range scriptCacheFactories(t) {
+		t.Run(name, func(t *testing.T) {
+			cache := newCache()
+
+			_, ok, err := cache.Get("not-a-real-hash")
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if ok {
+				t.Fatal("expected a cache miss for an absent hash")
+			}
+		})
+	}
+}
+
+func TestScriptCachePutRejectsHashMismatch(t *testing.T) {
+	script := &Script{Language: ScriptLanguageNative, Script: "deadbeef"}
+
+	for name, newCache := range scriptCacheFactories(t) {
+		t.Run(name, func(t *testing.T) {
+			cache := newCache()
+
+			if err := cache.Put("0000000000000000000000000000000000000000000000000000", script); err == nil {
+				t.Fatal("expected Put to reject a hash that doesn't match the script")
+			}
+
+			if _, ok, err := cache.Get("0000000000000000000000000000000000000000000000000000"); err != nil || ok {
+				t.Fatalf("expected no entry to have been stored, got ok=%v err=%v", ok, err)
+			}
+		})
+	}
+}
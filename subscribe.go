@@ -0,0 +1,296 @@
+// Copyright 2022 SundaeSwap Labs, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software
+// is furnished to do so, subject to the following conditions:
+//
+// Licensed under the MIT License;
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://opensource.org/licenses/MIT
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kugo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/SundaeSwap-finance/ogmigo/v6"
+	"github.com/gorilla/websocket"
+)
+
+// MatchEventType identifies the kind of change carried by a MatchEvent.
+type MatchEventType int
+
+const (
+	MatchEventAdded MatchEventType = iota
+	MatchEventRemoved
+	MatchEventRollback
+)
+
+// MatchEvent is a single update pushed by Kupo's streaming matches
+// endpoint: a match entering or leaving the index, or a rollback to an
+// earlier chain point.
+type MatchEvent struct {
+	Type  MatchEventType
+	Match Match
+	Point Point
+}
+
+// subscribeOptions configures a single SubscribeMatches call.
+type subscribeOptions struct {
+	since        *Point
+	pingInterval time.Duration
+	minBackoff   time.Duration
+	maxBackoff   time.Duration
+}
+
+// SubscribeOption configures SubscribeMatches.
+type SubscribeOption func(*subscribeOptions)
+
+// WithSince resumes the subscription from the given chain point instead
+// of the tip, useful when recovering from a previous run.
+func WithSince(point Point) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.since = &point
+	}
+}
+
+// WithPingInterval overrides how often a keepalive ping is sent on the
+// WebSocket connection. Defaults to 30s.
+func WithPingInterval(d time.Duration) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.pingInterval = d
+	}
+}
+
+func newSubscribeOptions(opts ...SubscribeOption) subscribeOptions {
+	o := subscribeOptions{
+		pingInterval: 30 * time.Second,
+		minBackoff:   250 * time.Millisecond,
+		maxBackoff:   30 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// wireMatchEvent mirrors the framed JSON messages Kupo pushes over its
+// /v1/matches WebSocket.
+type wireMatchEvent struct {
+	Match    *Match `json:"match,omitempty"`
+	Removed  bool   `json:"removed,omitempty"`
+	Rollback *Point `json:"rollback,omitempty"`
+}
+
+// SubscribeMatches dials Kupo's streaming /v1/matches endpoint for the
+// given pattern and pushes Added/Removed/Rollback events to the returned
+// channel as the chain advances. Transient connection failures (dropped
+// sockets, timeouts) are retried internally with exponential backoff,
+// resuming from the last point seen. The channels are closed once ctx
+// is cancelled.
+func (c *Client) SubscribeMatches(
+	ctx context.Context,
+	pattern string,
+	opts ...SubscribeOption,
+) (<-chan MatchEvent, <-chan error, error) {
+	cfg := newSubscribeOptions(opts...)
+
+	wsURL, err := c.matchesWebsocketURL(pattern)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	events := make(chan MatchEvent)
+	errs := make(chan error, 1)
+
+	go c.runMatchesSubscription(ctx, wsURL, cfg, events, errs)
+
+	return events, errs, nil
+}
+
+func (c *Client) matchesWebsocketURL(pattern string) (string, error) {
+	u, err := url.Parse(c.options.endpoint)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse endpoint %v: %w", c.options.endpoint, err)
+	}
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	default:
+		u.Scheme = "ws"
+	}
+	u.Path = "/v1/matches"
+	q := u.Query()
+	q.Set("pattern", pattern)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+func (c *Client) runMatchesSubscription(
+	ctx context.Context,
+	wsURL string,
+	cfg subscribeOptions,
+	events chan<- MatchEvent,
+	errs chan<- error,
+) {
+	defer close(events)
+	defer close(errs)
+
+	since := cfg.since
+	backoff := cfg.minBackoff
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		lastSeen, err := c.consumeMatchesConnection(ctx, wsURL, since, cfg, events)
+		if lastSeen != nil {
+			since = lastSeen
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			select {
+			case errs <- err:
+			default:
+			}
+			c.options.logger.Info(
+				"SubscribeMatches() reconnecting",
+				ogmigo.KV("err", err.Error()),
+				ogmigo.KV("backoff", backoff.String()),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > cfg.maxBackoff {
+			backoff = cfg.maxBackoff
+		}
+	}
+}
+
+// consumeMatchesConnection dials a single WebSocket connection and reads
+// from it until it fails or ctx is cancelled, returning the last chain
+// point observed so the caller can resume from there.
+func (c *Client) consumeMatchesConnection(
+	ctx context.Context,
+	wsURL string,
+	since *Point,
+	cfg subscribeOptions,
+	events chan<- MatchEvent,
+) (*Point, error) {
+	dialURL := wsURL
+	if since != nil {
+		sep := "&"
+		if !strings.Contains(dialURL, "?") {
+			sep = "?"
+		}
+		dialURL = fmt.Sprintf("%s%sslot=%d&header_hash=%s", dialURL, sep, since.Slot, since.Header)
+	}
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, dialURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to dial matches websocket: %w", err)
+	}
+	defer conn.Close()
+
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * cfg.pingInterval))
+	})
+	_ = conn.SetReadDeadline(time.Now().Add(2 * cfg.pingInterval))
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-stop:
+		}
+	}()
+	defer func() {
+		close(stop)
+		<-done
+	}()
+
+	ticker := time.NewTicker(cfg.pingInterval)
+	defer ticker.Stop()
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	var last *Point
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return last, fmt.Errorf("matches websocket closed: %w", err)
+		}
+
+		var wire wireMatchEvent
+		if err := json.Unmarshal(data, &wire); err != nil {
+			return last, fmt.Errorf("unable to decode match event %s: %w", data, err)
+		}
+
+		switch {
+		case wire.Rollback != nil:
+			point := *wire.Rollback
+			last = &point
+			select {
+			case events <- MatchEvent{Type: MatchEventRollback, Point: point}:
+			case <-ctx.Done():
+				return last, nil
+			}
+		case wire.Match != nil && wire.Removed:
+			point := wire.Match.CreatedAt
+			last = &point
+			select {
+			case events <- MatchEvent{Type: MatchEventRemoved, Match: *wire.Match, Point: point}:
+			case <-ctx.Done():
+				return last, nil
+			}
+		case wire.Match != nil:
+			point := wire.Match.CreatedAt
+			last = &point
+			select {
+			case events <- MatchEvent{Type: MatchEventAdded, Match: *wire.Match, Point: point}:
+			case <-ctx.Done():
+				return last, nil
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2022 SundaeSwap Labs, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software
+// is furnished to do so, subject to the following conditions:
+//
+// Licensed under the MIT License;
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://opensource.org/licenses/MIT
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kugo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// TestConsumeMatchesConnectionReturnsOnReadError guards against the
+// watchdog goroutine's done channel only closing on ctx cancellation:
+// a plain server-side disconnect (no ctx cancellation involved) must
+// still make consumeMatchesConnection return so the reconnect loop in
+// runMatchesSubscription can redial.
+func TestConsumeMatchesConnectionReturnsOnReadError(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		match := Match{CreatedAt: Point{Slot: 42}}
+		data, _ := json.Marshal(wireMatchEvent{Match: &match, Removed: true})
+		if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			t.Errorf("write: %v", err)
+		}
+		// Closing here simulates a transient server-side drop that is
+		// unrelated to context cancellation.
+	}))
+	defer srv.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(srv.URL, "http")
+
+	c := New()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events := make(chan MatchEvent, 1)
+	cfg := newSubscribeOptions(WithPingInterval(time.Minute))
+
+	done := make(chan struct{})
+	var last *Point
+	var err error
+	go func() {
+		last, err = c.consumeMatchesConnection(ctx, wsURL, nil, cfg, events)
+		close(done)
+	}()
+
+	select {
+	case ev := <-events:
+		if ev.Type != MatchEventRemoved {
+			t.Fatalf("expected MatchEventRemoved, got %v", ev.Type)
+		}
+		if ev.Point.Slot != 42 {
+			t.Fatalf("expected removed event to carry point slot 42, got %v", ev.Point.Slot)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for removed event")
+	}
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("consumeMatchesConnection did not return after the connection closed")
+	}
+
+	if err == nil {
+		t.Fatal("expected an error once the server closed the connection")
+	}
+	if last == nil || last.Slot != 42 {
+		t.Fatalf("expected last checkpoint slot 42, got %v", last)
+	}
+}